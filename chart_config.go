@@ -0,0 +1,177 @@
+package goldmark_chart
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RenderPoint is a single (key, value) pair in a dataset. Keys may be
+// numeric or string-valued (e.g. category labels vs. a time series).
+type RenderPoint struct {
+	Key   interface{} `yaml:"key"`
+	Value float64     `yaml:"value"`
+}
+
+// AxisConfig is the user-facing YAML shape for `xAxis`/`yAxis`.
+type AxisConfig struct {
+	Title      string   `yaml:"title"`
+	Min        *float64 `yaml:"min"`
+	Max        *float64 `yaml:"max"`
+	Log        bool     `yaml:"log"`
+	TickFormat string   `yaml:"tickFormat"`
+}
+
+// DatasetConfig is one entry of the user-facing `datasets` list.
+type DatasetConfig struct {
+	Label string        `yaml:"label"`
+	Color string        `yaml:"color"`
+	Data  []RenderPoint `yaml:"data"`
+}
+
+// ChartConfig is the YAML schema accepted inside a ```vis fenced block. It
+// supports both the original flat shape (`layout`/`data` at the top level,
+// a single implicit dataset) and the richer multi-dataset shape
+// (`datasets`, per-axis config, legend, stacking, theme).
+type ChartConfig struct {
+	Layout  string `yaml:"layout"`
+	Height  string `yaml:"height"`
+	Label   string `yaml:"label"`
+	Title   string `yaml:"title"`
+	Color   string `yaml:"color"`
+	Theme   string `yaml:"theme"`
+	Legend  string `yaml:"legend"`
+	Stacked bool   `yaml:"stacked"`
+
+	XAxis *AxisConfig `yaml:"xAxis"`
+	YAxis *AxisConfig `yaml:"yAxis"`
+
+	// Datasets is the new multi-series shape. When absent, Data/Label/Color
+	// are treated as a single implicit dataset for backwards compatibility.
+	Datasets []DatasetConfig `yaml:"datasets"`
+	Data     []RenderPoint   `yaml:"data"`
+}
+
+// RenderAxis is the resolved, renderer-facing form of AxisConfig.
+type RenderAxis struct {
+	Title      string
+	Min        *float64
+	Max        *float64
+	Log        bool
+	TickFormat string
+}
+
+// RenderDataset is one resolved series, ready for a Renderer.
+type RenderDataset struct {
+	Label  string
+	Color  string
+	Points []RenderPoint
+}
+
+type RenderChartData struct {
+	Type   string
+	Height string
+	Title  string
+	Theme  string
+	Legend string
+
+	Stacked bool
+	XAxis   *RenderAxis
+	YAxis   *RenderAxis
+
+	Datasets []RenderDataset
+
+	// Label, Color, Points and KeysNumeric mirror the first dataset, kept
+	// for Renderer implementations that only understand a single series.
+	Label       string
+	Color       string
+	Points      []RenderPoint
+	KeysNumeric bool
+
+	// Hash is a sha256 hex digest of the source chart block, stable across
+	// rebuilds. Renderer implementations use it as a DOM id / cache key.
+	Hash string
+}
+
+// ParseChartData decodes the contents of a ```vis fenced block. It accepts
+// YAML rather than the ad-hoc key/value format this parser started with;
+// the old flat `layout:`/`data:` shape already happens to be valid YAML,
+// so existing documents keep working unchanged.
+func ParseChartData(input string) (RenderChartData, error) {
+	var cfg ChartConfig
+	if err := yaml.Unmarshal([]byte(input), &cfg); err != nil {
+		return RenderChartData{}, fmt.Errorf("failed to parse chart config: %w", err)
+	}
+
+	if cfg.Layout == "" {
+		return RenderChartData{}, errors.New("layout not found")
+	}
+
+	// Translate the legacy flat shape (top-level `data`) into a single
+	// implicit dataset when no explicit `datasets` list was given.
+	datasetConfigs := cfg.Datasets
+	if len(datasetConfigs) == 0 {
+		if len(cfg.Data) == 0 {
+			return RenderChartData{}, errors.New("data not found")
+		}
+		datasetConfigs = []DatasetConfig{{
+			Label: cfg.Label,
+			Color: cfg.Color,
+			Data:  cfg.Data,
+		}}
+	}
+
+	datasets := make([]RenderDataset, len(datasetConfigs))
+	for i, dc := range datasetConfigs {
+		if len(dc.Data) == 0 {
+			return RenderChartData{}, fmt.Errorf("dataset %d: data not found", i)
+		}
+		datasets[i] = RenderDataset{
+			Label:  dc.Label,
+			Color:  dc.Color,
+			Points: dc.Data,
+		}
+	}
+
+	return RenderChartData{
+		Type:        cfg.Layout,
+		Height:      cfg.Height,
+		Title:       cfg.Title,
+		Theme:       cfg.Theme,
+		Legend:      cfg.Legend,
+		Stacked:     cfg.Stacked,
+		XAxis:       toRenderAxis(cfg.XAxis),
+		YAxis:       toRenderAxis(cfg.YAxis),
+		Datasets:    datasets,
+		Label:       datasets[0].Label,
+		Color:       datasets[0].Color,
+		Points:      datasets[0].Points,
+		KeysNumeric: keysNumeric(datasets[0].Points),
+	}, nil
+}
+
+func toRenderAxis(a *AxisConfig) *RenderAxis {
+	if a == nil {
+		return nil
+	}
+	return &RenderAxis{
+		Title:      a.Title,
+		Min:        a.Min,
+		Max:        a.Max,
+		Log:        a.Log,
+		TickFormat: a.TickFormat,
+	}
+}
+
+func keysNumeric(points []RenderPoint) bool {
+	for _, p := range points {
+		key, ok := p.Key.(string)
+		if ok {
+			if _, err := fmt.Sscanf(key, "%v", new(float64)); err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
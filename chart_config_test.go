@@ -0,0 +1,94 @@
+package goldmark_chart
+
+import "testing"
+
+func TestParseChartDataLegacy(t *testing.T) {
+	input := `
+layout: bar
+label: widgets
+data:
+  - key: Jan
+    value: 1
+  - key: Feb
+    value: 2
+`
+	cd, err := ParseChartData(input)
+	if err != nil {
+		t.Fatalf("ParseChartData returned error: %v", err)
+	}
+	if len(cd.Datasets) != 1 {
+		t.Fatalf("expected one implicit dataset, got %d", len(cd.Datasets))
+	}
+	if cd.Label != "widgets" || len(cd.Points) != 2 {
+		t.Fatalf("expected legacy fields to mirror the implicit dataset, got %+v", cd)
+	}
+}
+
+func TestParseChartDataMultiDataset(t *testing.T) {
+	input := `
+layout: line
+title: Sales
+theme: light
+legend: bottom
+stacked: true
+xAxis:
+  title: Month
+yAxis:
+  title: Revenue
+  min: 0
+datasets:
+  - label: 2024
+    color: "#ff0000"
+    data:
+      - key: Jan
+        value: 1
+      - key: Feb
+        value: 2
+  - label: 2025
+    data:
+      - key: Jan
+        value: 3
+      - key: Feb
+        value: 4
+`
+	cd, err := ParseChartData(input)
+	if err != nil {
+		t.Fatalf("ParseChartData returned error: %v", err)
+	}
+	if len(cd.Datasets) != 2 {
+		t.Fatalf("expected two datasets, got %d", len(cd.Datasets))
+	}
+	if !cd.Stacked {
+		t.Fatalf("expected Stacked to be true")
+	}
+	if cd.Legend != "bottom" {
+		t.Fatalf("expected Legend to be \"bottom\", got %q", cd.Legend)
+	}
+	if cd.Theme != "light" {
+		t.Fatalf("expected Theme to be \"light\", got %q", cd.Theme)
+	}
+	if cd.XAxis == nil || cd.XAxis.Title != "Month" {
+		t.Fatalf("expected XAxis.Title to be \"Month\", got %+v", cd.XAxis)
+	}
+	if cd.YAxis == nil || cd.YAxis.Title != "Revenue" || cd.YAxis.Min == nil || *cd.YAxis.Min != 0 {
+		t.Fatalf("expected YAxis.Title \"Revenue\" and Min 0, got %+v", cd.YAxis)
+	}
+	if cd.Datasets[0].Label != "2024" || cd.Datasets[1].Label != "2025" {
+		t.Fatalf("expected dataset labels to be preserved in order, got %+v", cd.Datasets)
+	}
+}
+
+func TestParseChartDataMalformed(t *testing.T) {
+	cases := map[string]string{
+		"missing layout": "data:\n  - key: a\n    value: 1\n",
+		"missing data":   "layout: bar\n",
+		"invalid yaml":   "layout: bar\ndata: [\n",
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseChartData(input); err == nil {
+				t.Fatalf("expected an error for %s", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package goldmark_chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChartTypeRenderer builds the Chart.js `data`/`options` config (as the
+// <script> tag returned by ChartJSRenderer) for one chart type. Registering
+// a new implementation is how downstream users add chart types this module
+// doesn't ship, like candlestick or sankey plugins.
+type ChartTypeRenderer interface {
+	Build(divID string, cd RenderChartData) (string, error)
+}
+
+// ChartTypeRegistry maps a chart type name (as written in `layout:`) to the
+// ChartTypeRenderer that knows how to build it. Each Chart extension
+// instance owns its own registry, so registering a custom type against one
+// goldmark.Markdown doesn't leak into another.
+type ChartTypeRegistry struct {
+	types map[string]ChartTypeRenderer
+}
+
+// NewChartTypeRegistry returns a registry seeded with every built-in chart
+// type.
+func NewChartTypeRegistry() *ChartTypeRegistry {
+	reg := &ChartTypeRegistry{types: map[string]ChartTypeRenderer{}}
+	for name, r := range builtinChartTypes {
+		reg.RegisterChartType(name, r)
+	}
+	return reg
+}
+
+// DefaultChartTypeRegistry is the registry ChartJSRenderer falls back to
+// when none is configured.
+func DefaultChartTypeRegistry() *ChartTypeRegistry {
+	return NewChartTypeRegistry()
+}
+
+// RegisterChartType adds or overrides the ChartTypeRenderer for name.
+func (reg *ChartTypeRegistry) RegisterChartType(name string, r ChartTypeRenderer) {
+	reg.types[strings.ToLower(strings.TrimSpace(name))] = r
+}
+
+// Build looks up cd.Type and delegates to its ChartTypeRenderer, falling
+// back to "bar" for unknown types (matching the module's original
+// behavior).
+func (reg *ChartTypeRegistry) Build(divID string, cd RenderChartData) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(cd.Type))
+	r, ok := reg.types[name]
+	if !ok {
+		r, ok = reg.types["bar"]
+		if !ok {
+			return "", fmt.Errorf("unknown chart type %q and no bar fallback registered", cd.Type)
+		}
+		name = "bar"
+	}
+	return r.Build(divID, cd)
+}
+
+var builtinChartTypes = map[string]ChartTypeRenderer{
+	"bar":       cartesianChartType{chartJSType: "bar"},
+	"line":      cartesianChartType{chartJSType: "line"},
+	"pie":       radialChartType{chartJSType: "pie"},
+	"doughnut":  radialChartType{chartJSType: "doughnut"},
+	"radar":     radialChartType{chartJSType: "radar"},
+	"polarArea": radialChartType{chartJSType: "polarArea"},
+	"scatter":   pointChartType{chartJSType: "scatter"},
+	"bubble":    bubbleChartType{},
+}
+
+// cartesianChartType covers chart types plotted against labelled x/y axes:
+// bar and line.
+type cartesianChartType struct {
+	chartJSType string
+}
+
+func (t cartesianChartType) Build(divID string, cd RenderChartData) (string, error) {
+	c := newChartJSCommon(cd)
+
+	labelsJSON, datasetsJSON, err := labelValueDatasetsJSON(cd)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s datasets: %w", t.chartJSType, err)
+	}
+
+	options := fmt.Sprintf(`{
+		responsive: true,
+		maintainAspectRatio: false,
+		plugins: {
+			legend: %s,
+			title:  { display: %s, text: %s, color: %s }
+		},
+		scales: {
+			x: %s,
+			y: %s
+		}
+	}`, legendOptionJSON(cd, c.UIColorJSON), c.TitleDisplay, c.TitleJSON, c.UIColorJSON,
+		axisOptionJSON(cd.XAxis, c.UIColorJSON, cd.Stacked), axisOptionJSON(cd.YAxis, c.UIColorJSON, cd.Stacked))
+
+	data := fmt.Sprintf(`{ labels: %s, datasets: %s }`, labelsJSON, datasetsJSON)
+	return wrapChartJS(divID, t.chartJSType, data, options), nil
+}
+
+// radialChartType covers chart types with no cartesian axes: pie,
+// doughnut, radar and polarArea. Radar/polarArea style their radial scale
+// the way bar/line style x/y, everything else is identical to the
+// original pie behavior.
+type radialChartType struct {
+	chartJSType string
+}
+
+func (t radialChartType) Build(divID string, cd RenderChartData) (string, error) {
+	c := newChartJSCommon(cd)
+
+	labelsJSON, datasetsJSON, err := labelValueDatasetsJSON(cd)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s datasets: %w", t.chartJSType, err)
+	}
+
+	base := fmt.Sprintf(`{
+		responsive: true,
+		maintainAspectRatio: false,
+		plugins: {
+			legend: %s,
+			title: { display: %s, text: %s, color: %s }
+		}`, legendOptionJSON(cd, c.UIColorJSON), c.TitleDisplay, c.TitleJSON, c.UIColorJSON)
+
+	var options string
+	switch t.chartJSType {
+	case "radar", "polarArea":
+		options = base + fmt.Sprintf(`,
+			scales: {
+				r: {
+					ticks: { color: %s },
+					grid:  { color: "rgba(255,255,255,0.1)" },
+					pointLabels: { color: %s }
+				}
+			}
+		}`, c.UIColorJSON, c.UIColorJSON)
+	default:
+		options = base + "\n\t\t}"
+	}
+
+	data := fmt.Sprintf(`{ labels: %s, datasets: %s }`, labelsJSON, datasetsJSON)
+	return wrapChartJS(divID, t.chartJSType, data, options), nil
+}
+
+// pointChartType covers chart types whose dataset is a list of {x,y}
+// points rather than parallel labels/values arrays: scatter.
+type pointChartType struct {
+	chartJSType string
+}
+
+func (t pointChartType) Build(divID string, cd RenderChartData) (string, error) {
+	c := newChartJSCommon(cd)
+
+	datasetsJSON, err := pointDatasetsJSON(cd, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal scatter datasets: %w", err)
+	}
+
+	options := fmt.Sprintf(`{
+		responsive: true,
+		maintainAspectRatio: false,
+		plugins: {
+			legend: %s,
+			title:  { display: %s, text: %s, color: %s }
+		},
+		scales: {
+			x: %s,
+			y: %s
+		}
+	}`, legendOptionJSON(cd, c.UIColorJSON), c.TitleDisplay, c.TitleJSON, c.UIColorJSON,
+		axisOptionJSON(cd.XAxis, c.UIColorJSON, cd.Stacked), axisOptionJSON(cd.YAxis, c.UIColorJSON, cd.Stacked))
+
+	data := fmt.Sprintf(`{ datasets: %s }`, datasetsJSON)
+	return wrapChartJS(divID, t.chartJSType, data, options), nil
+}
+
+// bubbleChartType is like pointChartType but each point also carries a
+// radius. RenderPoint has no radius field, so points are spread along x by
+// index and given a fixed radius; users who need per-point radii can
+// register a custom ChartTypeRenderer.
+type bubbleChartType struct{}
+
+const defaultBubbleRadius = 8
+
+func (bubbleChartType) Build(divID string, cd RenderChartData) (string, error) {
+	c := newChartJSCommon(cd)
+
+	datasetsJSON, err := pointDatasetsJSON(cd, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal bubble datasets: %w", err)
+	}
+
+	options := fmt.Sprintf(`{
+		responsive: true,
+		maintainAspectRatio: false,
+		plugins: {
+			legend: %s,
+			title:  { display: %s, text: %s, color: %s }
+		},
+		scales: {
+			x: %s,
+			y: %s
+		}
+	}`, legendOptionJSON(cd, c.UIColorJSON), c.TitleDisplay, c.TitleJSON, c.UIColorJSON,
+		axisOptionJSON(cd.XAxis, c.UIColorJSON, cd.Stacked), axisOptionJSON(cd.YAxis, c.UIColorJSON, cd.Stacked))
+
+	data := fmt.Sprintf(`{ datasets: %s }`, datasetsJSON)
+	return wrapChartJS(divID, "bubble", data, options), nil
+}
+
+// point is the Chart.js {x, y[, r]} shape used by scatter/bubble datasets.
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	R float64 `json:"r,omitempty"`
+}
+
+// pointDataset is the Chart.js dataset shape for scatter/bubble chart
+// types, whose data is a list of points rather than parallel labels/values
+// arrays.
+type pointDataset struct {
+	Label           string  `json:"label"`
+	Data            []point `json:"data"`
+	BorderWidth     int     `json:"borderWidth"`
+	BackgroundColor string  `json:"backgroundColor,omitempty"`
+	BorderColor     string  `json:"borderColor,omitempty"`
+}
+
+// pointDatasetsJSON builds one Chart.js dataset per series in cd. When
+// withRadius is true (bubble charts), points are spread along x by index
+// and given a fixed radius, since RenderPoint has no radius field; callers
+// needing per-point radii can register a custom ChartTypeRenderer. When
+// false (scatter charts), each point's key is converted to its x value.
+func pointDatasetsJSON(cd RenderChartData, withRadius bool) ([]byte, error) {
+	sets := chartDatasets(cd)
+
+	jsDatasets := make([]pointDataset, len(sets))
+	for i, ds := range sets {
+		points := make([]point, len(ds.Points))
+		for j, p := range ds.Points {
+			if withRadius {
+				points[j] = point{X: float64(j), Y: p.Value, R: defaultBubbleRadius}
+				continue
+			}
+			x, ok := toFloat64(p.Key)
+			if !ok {
+				return nil, fmt.Errorf("dataset %d point %d: key %v is not numeric", i, j, p.Key)
+			}
+			points[j] = point{X: x, Y: p.Value}
+		}
+		jsDatasets[i] = pointDataset{
+			Label:           ds.Label,
+			Data:            points,
+			BorderWidth:     1,
+			BackgroundColor: ds.Color,
+			BorderColor:     ds.Color,
+		}
+	}
+
+	return json.Marshal(jsDatasets)
+}
+
+// toFloat64 converts a RenderPoint.Key into a float64, handling every
+// numeric kind a YAML or JSON scalar can decode into. gopkg.in/yaml.v3
+// decodes a whole number like `key: 5` into an int, not a float64, so a
+// bare `p.Key.(float64)` type assertion silently treats every
+// integer-keyed point as x=0 instead of converting it.
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
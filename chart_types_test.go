@@ -0,0 +1,61 @@
+package goldmark_chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChartTypeRegistryFallsBackToBar(t *testing.T) {
+	reg := NewChartTypeRegistry()
+	cd := RenderChartData{
+		Type:  "not-a-real-type",
+		Label: "widgets",
+		Points: []RenderPoint{
+			{Key: "Jan", Value: 1},
+		},
+	}
+
+	out, err := reg.Build("div1", cd)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if out == "" {
+		t.Fatalf("expected non-empty output from the bar fallback")
+	}
+	if !strings.Contains(out, `type: "bar"`) {
+		t.Fatalf("expected an unknown type to build a bar chart, got: %s", out)
+	}
+}
+
+func TestPointChartTypeIntegerKeys(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "scatter",
+		Label: "series",
+		Points: []RenderPoint{
+			{Key: 1, Value: 10},
+			{Key: 2, Value: 20},
+		},
+	}
+
+	out, err := pointChartType{chartJSType: "scatter"}.Build("div1", cd)
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if !strings.Contains(out, `"x":1`) || !strings.Contains(out, `"x":2`) {
+		t.Fatalf("expected integer keys to convert to numeric x values, got: %s", out)
+	}
+}
+
+func TestPointChartTypeNonNumericKeyErrors(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "scatter",
+		Label: "series",
+		Points: []RenderPoint{
+			{Key: "not-a-number", Value: 10},
+		},
+	}
+
+	if _, err := (pointChartType{chartJSType: "scatter"}).Build("div1", cd); err == nil {
+		t.Fatalf("expected an error for a non-numeric scatter key")
+	}
+}
@@ -0,0 +1,212 @@
+package goldmark_chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ChartJSRenderer renders a chart block as an HTML <canvas> plus a deferred
+// <script> tag that hands the data to Chart.js in the browser. This was the
+// module's original (and only) behavior.
+type ChartJSRenderer struct {
+	// Registry selects which ChartTypeRenderer builds the Chart.js config
+	// for cd.Type. Defaults to DefaultChartTypeRegistry() when nil.
+	Registry *ChartTypeRegistry
+}
+
+func (r *ChartJSRenderer) Render(cd RenderChartData) ([]byte, error) {
+	reg := r.Registry
+	if reg == nil {
+		reg = DefaultChartTypeRegistry()
+	}
+
+	script, err := reg.Build(cd.Hash, cd)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []byte(fmt.Sprintf(`<div style="position:relative;width:100%%;height:%s"><canvas id="%s"></canvas></div>`, cd.Height, cd.Hash))
+	out = append(out, []byte(script)...)
+	return out, nil
+}
+
+// chartJSCommon bundles the pieces of a chart.js config that are the same
+// regardless of chart type: the UI color and the title.
+type chartJSCommon struct {
+	UIColorJSON  []byte
+	TitleJSON    []byte
+	TitleDisplay string
+}
+
+func newChartJSCommon(cd RenderChartData) chartJSCommon {
+	uiColor := cd.Color
+	if strings.TrimSpace(uiColor) == "" {
+		uiColor = themeColor(cd.Theme)
+	}
+	uiColorJSON, _ := json.Marshal(uiColor)
+
+	titleText := cd.Title
+	if strings.TrimSpace(titleText) == "" {
+		titleText = cd.Label
+	}
+	titleJSON, _ := json.Marshal(titleText)
+	titleDisplay := "false"
+	if strings.TrimSpace(titleText) != "" {
+		titleDisplay = "true"
+	}
+
+	return chartJSCommon{
+		UIColorJSON:  uiColorJSON,
+		TitleJSON:    titleJSON,
+		TitleDisplay: titleDisplay,
+	}
+}
+
+// themeColor resolves cd.Theme into the default UI color used when a chart
+// block doesn't set `color` explicitly. "dark" (the module's original,
+// implicit behavior) is the fallback for an empty or unrecognized theme.
+func themeColor(theme string) string {
+	switch strings.ToLower(strings.TrimSpace(theme)) {
+	case "light":
+		return "#333333"
+	default:
+		return "#dddddd"
+	}
+}
+
+// chartDatasets resolves the series to render for cd, falling back to a
+// single implicit series built from Label/Color/Points so RenderChartData
+// values built by hand (or by older code) without Datasets populated still
+// render the same as before.
+func chartDatasets(cd RenderChartData) []RenderDataset {
+	if len(cd.Datasets) > 0 {
+		return cd.Datasets
+	}
+	return []RenderDataset{{Label: cd.Label, Color: cd.Color, Points: cd.Points}}
+}
+
+// labelValueDataset is the Chart.js dataset shape used by chart types whose
+// data is a value per shared label: bar, line, pie, doughnut, radar,
+// polarArea.
+type labelValueDataset struct {
+	Label           string    `json:"label"`
+	Data            []float64 `json:"data"`
+	BorderWidth     int       `json:"borderWidth"`
+	BackgroundColor string    `json:"backgroundColor,omitempty"`
+	BorderColor     string    `json:"borderColor,omitempty"`
+	Stack           string    `json:"stack,omitempty"`
+}
+
+// labelValueDatasetsJSON builds one Chart.js dataset per series in cd. The
+// first series' keys are used as the shared `labels` array, since Chart.js
+// expects every dataset to line up against the same labels.
+func labelValueDatasetsJSON(cd RenderChartData) (labelsJSON []byte, datasetsJSON []byte, err error) {
+	sets := chartDatasets(cd)
+
+	labels := make([]interface{}, len(sets[0].Points))
+	for i, p := range sets[0].Points {
+		labels[i] = p.Key
+	}
+	if labelsJSON, err = json.Marshal(labels); err != nil {
+		return nil, nil, err
+	}
+
+	jsDatasets := make([]labelValueDataset, len(sets))
+	for i, ds := range sets {
+		values := make([]float64, len(ds.Points))
+		for j, p := range ds.Points {
+			values[j] = p.Value
+		}
+		jsDatasets[i] = labelValueDataset{
+			Label:           ds.Label,
+			Data:            values,
+			BorderWidth:     1,
+			BackgroundColor: ds.Color,
+			BorderColor:     ds.Color,
+		}
+		if cd.Stacked {
+			jsDatasets[i].Stack = "stack0"
+		}
+	}
+
+	datasetsJSON, err = json.Marshal(jsDatasets)
+	return labelsJSON, datasetsJSON, err
+}
+
+// legendOptionJSON builds plugins.legend. cd.Legend is a Chart.js legend
+// position ("top", "bottom", "left", "right"); "none" hides the legend
+// entirely; empty keeps the module's original default (shown, unpositioned).
+func legendOptionJSON(cd RenderChartData, uiColorJSON []byte) string {
+	switch legend := strings.ToLower(strings.TrimSpace(cd.Legend)); legend {
+	case "none":
+		return `{ display: false }`
+	case "":
+		return fmt.Sprintf(`{ labels: { color: %s } }`, uiColorJSON)
+	default:
+		positionJSON, _ := json.Marshal(legend)
+		return fmt.Sprintf(`{ position: %s, labels: { color: %s } }`, positionJSON, uiColorJSON)
+	}
+}
+
+// tickFormatCallbackJS turns a TickFormat template into a Chart.js
+// ticks.callback function. The template uses Go's "%v" as the value
+// placeholder, the same convention fmt.Sprintf("%v", ...) uses elsewhere in
+// this package to stringify a point's key (see renderGoChart).
+func tickFormatCallbackJS(format string) string {
+	formatJSON, _ := json.Marshal(format)
+	return fmt.Sprintf(`function(value) { return %s.replace("%%v", value); }`, formatJSON)
+}
+
+// axisOptionJSON builds one scales.x/scales.y entry: the shared tick/grid
+// styling, plus anything axis and stacked contribute on top of it. axis may
+// be nil, meaning the block didn't set xAxis/yAxis.
+func axisOptionJSON(axis *RenderAxis, uiColorJSON []byte, stacked bool) string {
+	ticksFields := []string{fmt.Sprintf(`color: %s`, uiColorJSON)}
+	if axis != nil && strings.TrimSpace(axis.TickFormat) != "" {
+		ticksFields = append(ticksFields, fmt.Sprintf(`callback: %s`, tickFormatCallbackJS(axis.TickFormat)))
+	}
+	fields := []string{
+		"ticks: { " + strings.Join(ticksFields, ", ") + " }",
+		`grid: { color: "rgba(255,255,255,0.1)" }`,
+	}
+	if stacked {
+		fields = append(fields, `stacked: true`)
+	}
+	if axis != nil {
+		if strings.TrimSpace(axis.Title) != "" {
+			titleJSON, _ := json.Marshal(axis.Title)
+			fields = append(fields, fmt.Sprintf(`title: { display: true, text: %s, color: %s }`, titleJSON, uiColorJSON))
+		}
+		if axis.Log {
+			fields = append(fields, `type: "logarithmic"`)
+		}
+		if axis.Min != nil {
+			minJSON, _ := json.Marshal(*axis.Min)
+			fields = append(fields, fmt.Sprintf(`min: %s`, minJSON))
+		}
+		if axis.Max != nil {
+			maxJSON, _ := json.Marshal(*axis.Max)
+			fields = append(fields, fmt.Sprintf(`max: %s`, maxJSON))
+		}
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// wrapChartJS produces the <script> tag shared by every built-in chart
+// type: it wires `data` and `options` up to a `new Chart(...)` call against
+// the canvas with id divID.
+func wrapChartJS(divID, chartType, data, options string) string {
+	return fmt.Sprintf(`
+	<script defer>
+		(function () {
+			const ctx = document.getElementById("%s").getContext("2d");
+			const config = {
+				type: %q,
+				data: %s,
+				options: %s
+			};
+			new Chart(ctx, config);
+		})();
+	</script>`, divID, chartType, data, options)
+}
@@ -0,0 +1,29 @@
+package goldmark_chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAxisOptionJSONTickFormat(t *testing.T) {
+	uiColorJSON := []byte(`"#333333"`)
+	axis := &RenderAxis{TickFormat: "$%v"}
+
+	out := axisOptionJSON(axis, uiColorJSON, false)
+	if !strings.Contains(out, `callback: function(value)`) {
+		t.Fatalf("expected a ticks.callback function, got: %s", out)
+	}
+	if !strings.Contains(out, `"$%v".replace("%v", value)`) {
+		t.Fatalf("expected the callback to use the tickFormat template, got: %s", out)
+	}
+}
+
+func TestAxisOptionJSONNoTickFormat(t *testing.T) {
+	uiColorJSON := []byte(`"#333333"`)
+	axis := &RenderAxis{Title: "Revenue"}
+
+	out := axisOptionJSON(axis, uiColorJSON, false)
+	if strings.Contains(out, "callback") {
+		t.Fatalf("expected no ticks.callback when tickFormat is unset, got: %s", out)
+	}
+}
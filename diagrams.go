@@ -0,0 +1,144 @@
+package goldmark_chart
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/blampe/goat/ascii"
+	"github.com/blampe/goat/svg"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// MermaidBlock holds a ```mermaid fenced code block. It is rendered
+// client-side by the Mermaid JS runtime, not in-process.
+type MermaidBlock struct {
+	ast.BaseBlock
+}
+
+var KindMermaidBlock = ast.NewNodeKind("MermaidBlock")
+
+func (n *MermaidBlock) Kind() ast.NodeKind {
+	return KindMermaidBlock
+}
+
+func (n *MermaidBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// GoatBlock holds a ```goat fenced code block containing ASCII art. It is
+// rendered to inline SVG at build time, so no JS runtime is required.
+type GoatBlock struct {
+	ast.BaseBlock
+}
+
+var KindGoatBlock = ast.NewNodeKind("GoatBlock")
+
+func (n *GoatBlock) Kind() ast.NodeKind {
+	return KindGoatBlock
+}
+
+func (n *GoatBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+var MERMAID_LANG = []byte("mermaid")
+var GOAT_LANG = []byte("goat")
+
+// mermaidFirstAttr marks the MermaidBlock node that should carry the
+// runtime <script> tag, so it is only emitted once per document.
+const mermaidFirstAttr = "data-goldmark-chart-mermaid-first"
+
+const defaultMermaidCDN = "https://cdn.jsdelivr.net/npm/mermaid@10/dist/mermaid.esm.min.mjs"
+
+// RenderMermaid emits a `<pre class="mermaid">` wrapper around the raw
+// diagram source. The Mermaid runtime itself is injected once, on the
+// first MermaidBlock encountered in the document.
+func (r *HTMLRenderer) RenderMermaid(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*MermaidBlock)
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	if _, ok := n.AttributeString(mermaidFirstAttr); ok {
+		cdn := r.MermaidCDN
+		if cdn == "" {
+			cdn = defaultMermaidCDN
+		}
+		fmt.Fprintf(w, `<script type="module">import mermaid from %q; mermaid.initialize({ startOnLoad: true });</script>`, cdn)
+	}
+
+	fmt.Fprint(w, `<pre class="mermaid">`)
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		_, _ = w.Write(util.EscapeHTML(line.Value(src)))
+	}
+	fmt.Fprint(w, `</pre>`)
+
+	return ast.WalkContinue, nil
+}
+
+// RenderGoat converts the ASCII art source into an inline SVG using a
+// pure-Go implementation, so no client-side runtime is needed.
+func (r *HTMLRenderer) RenderGoat(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*GoatBlock)
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	input := []byte{}
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		input = append(input, line.Value(src)...)
+	}
+
+	if err := validateGoatInput(input); err != nil {
+		return ast.WalkContinue, fmt.Errorf("invalid goat block: %w", err)
+	}
+
+	out, err := renderGoatSVG(input)
+	if err != nil {
+		return ast.WalkContinue, err
+	}
+	_, err = w.Write(out)
+	return ast.WalkContinue, err
+}
+
+// validateGoatInput rejects input that would otherwise reach one of
+// goat's log.Fatal calls (svg.WriteCanvas on empty input, svg.Writetext
+// on a NUL byte among the diagram's text runes). log.Fatal calls
+// os.Exit, which cannot be caught by recover(), so malformed input is
+// checked here, before calling into goat at all, rather than relying on
+// goat to fail gracefully.
+func validateGoatInput(input []byte) error {
+	if len(bytes.TrimSpace(input)) == 0 {
+		return errors.New("goat block is empty")
+	}
+	for _, r := range string(input) {
+		switch r {
+		case '\n', '\r', '\t':
+			continue
+		}
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("goat block contains control character %U", r)
+		}
+	}
+	return nil
+}
+
+// renderGoatSVG parses ASCII art into goat's canvas representation and
+// writes it out as SVG. The actual drawing/rendering entry points live in
+// goat's ascii/svg subpackages, not the root package.
+func renderGoatSVG(input []byte) ([]byte, error) {
+	config, err := svg.NewConfig(ascii.ReservedSet, make(svg.MarkBindingMap))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build goat render config: %w", err)
+	}
+
+	canvas := ascii.NewCanvas(&config, bytes.NewReader(input))
+
+	var buf bytes.Buffer
+	svg.WriteCanvas(&config, canvas, true, "", nil, &buf)
+	return buf.Bytes(), nil
+}
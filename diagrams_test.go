@@ -0,0 +1,93 @@
+package goldmark_chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+)
+
+func TestRenderGoatSVG(t *testing.T) {
+	input := []byte(strings.TrimSpace(`
++-------+     +-------+
+|   A   |---->|   B   |
++-------+     +-------+
+`))
+
+	out, err := renderGoatSVG(input)
+	if err != nil {
+		t.Fatalf("renderGoatSVG returned error: %v", err)
+	}
+	if !bytes.Contains(out, []byte("<svg")) {
+		t.Fatalf("expected output to contain an <svg> element, got: %s", out)
+	}
+}
+
+func TestGoatBlockEndToEnd(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewChart(WithGoat(true))))
+
+	source := []byte("```goat\n+---+\n| A |\n+---+\n```\n")
+
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf); err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<svg") {
+		t.Fatalf("expected rendered document to contain an <svg> element, got: %s", buf.String())
+	}
+}
+
+func TestValidateGoatInput(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   []byte
+		wantErr bool
+	}{
+		{"valid ascii art", []byte("+---+\n| A |\n+---+\n"), false},
+		{"empty", []byte(""), true},
+		{"blank", []byte("   \n\t\n"), true},
+		{"nul byte", []byte("hello\x00world"), true},
+		{"other control character", []byte("hello\x01world"), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateGoatInput(tc.input)
+			if tc.wantErr && err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+// TestGoatBlockEmptyDoesNotCrash guards against a regression to goat's
+// unrecoverable log.Fatal on empty input (verified to call os.Exit and
+// take down the whole process): an empty ```goat block must surface as an
+// ordinary Convert error, not a process exit.
+func TestGoatBlockEmptyDoesNotCrash(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewChart(WithGoat(true))))
+
+	source := []byte("```goat\n```\n")
+
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf); err == nil {
+		t.Fatalf("expected an error for an empty goat block, got none")
+	}
+}
+
+// TestGoatBlockNULByteDoesNotCrash guards against a regression to goat's
+// unrecoverable log.Fatal on a NUL byte in the diagram text.
+func TestGoatBlockNULByteDoesNotCrash(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewChart(WithGoat(true))))
+
+	source := []byte("```goat\nhello\x00world\n```\n")
+
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf); err == nil {
+		t.Fatalf("expected an error for a goat block containing a NUL byte, got none")
+	}
+}
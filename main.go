@@ -4,11 +4,8 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"strings"
 
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/parser"
@@ -32,16 +29,24 @@ func (n *ChartBlock) Dump(source []byte, level int) {
 	ast.DumpHelper(n, source, level, nil, nil)
 }
 
+// Transformer turns fenced code blocks written in one of the supported
+// diagram languages into their corresponding AST node kind. Mermaid and
+// Goat support are opt-in since they are not the chart-rendering concern
+// this module started as.
 type Transformer struct {
+	EnableMermaid bool
+	EnableGoat    bool
 }
 
 var VIS_LANG = []byte("vis")
 
-// Transform code blocks into chart blocks
+// Transform code blocks into chart/diagram blocks
 func (s *Transformer) Transform(doc *ast.Document, reader text.Reader, pctx parser.Context) {
-	var blocks []*ast.FencedCodeBlock
+	var chartBlocks []*ast.FencedCodeBlock
+	var mermaidBlocks []*ast.FencedCodeBlock
+	var goatBlocks []*ast.FencedCodeBlock
 
-	// Collect all chart blocks
+	// Collect all chart/diagram blocks
 	ast.Walk(doc, func(node ast.Node, enter bool) (ast.WalkStatus, error) {
 		if !enter {
 			return ast.WalkContinue, nil
@@ -52,240 +57,76 @@ func (s *Transformer) Transform(doc *ast.Document, reader text.Reader, pctx pars
 			return ast.WalkContinue, nil
 		}
 
-		lang := cb.Language(reader.Source())
-		if !bytes.Equal(lang, VIS_LANG) {
-			return ast.WalkContinue, nil
+		switch lang := cb.Language(reader.Source()); {
+		case bytes.Equal(lang, VIS_LANG):
+			chartBlocks = append(chartBlocks, cb)
+		case s.EnableMermaid && bytes.Equal(lang, MERMAID_LANG):
+			mermaidBlocks = append(mermaidBlocks, cb)
+		case s.EnableGoat && bytes.Equal(lang, GOAT_LANG):
+			goatBlocks = append(goatBlocks, cb)
 		}
-
-		blocks = append(blocks, cb)
 		return ast.WalkContinue, nil
 	})
 
-	// Nothing to do
-	if len(blocks) == 0 {
-		return
-	}
-
 	// Modify those blocks in place
-	for _, cb := range blocks {
+	for _, cb := range chartBlocks {
 		b := new(ChartBlock)
 		b.SetLines(cb.Lines())
-
-		parent := cb.Parent()
-		if parent != nil {
-			parent.ReplaceChild(parent, cb, b)
-		}
+		replaceWithBlock(cb, b)
 	}
-}
 
-type HTMLRenderer struct {
-	// Options
-}
-
-func (r *HTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
-	reg.Register(KindChartBlock, r.Render)
-}
-
-type RenderPoint struct {
-	Key   interface{} `json:"key,string"`
-	Value float64
-}
-
-type RenderChartData struct {
-	Type        string
-	Height      string
-	Label       string
-	Title       string
-	Points      []RenderPoint
-	KeysNumeric bool
-	Color       string
-}
-
-func ParseChartData(input string) (RenderChartData, error) {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
-
-	var chart_type string
-	var chart_height string
-	var chart_label string
-	var chart_title string
-	var chart_color string
-
-	var data_lines []string
-	in_data := false
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		switch {
-		case strings.HasPrefix(line, "layout:"):
-			chart_type = strings.TrimSpace(strings.TrimPrefix(line, "layout:"))
-		case strings.HasPrefix(line, "height:"):
-			chart_height = strings.TrimSpace(strings.TrimPrefix(line, "height:"))
-		case strings.HasPrefix(line, "label:"):
-			chart_label = strings.TrimSpace(strings.TrimPrefix(line, "label:"))
-		case strings.HasPrefix(line, "title:"):
-			chart_title = strings.TrimSpace(strings.TrimPrefix(line, "title:"))
-		case strings.HasPrefix(line, "color:"):
-			chart_color = strings.TrimSpace(strings.TrimPrefix(line, "color:"))
-		case strings.HasPrefix(line, "data:"):
-			in_data = true
-			// add everything after `data:` in this line (if `[ ...` is on same line)
-			if i := strings.Index(line, "["); i != -1 {
-				data_lines = append(data_lines, line[i:])
-			}
-		default:
-			if in_data {
-				data_lines = append(data_lines, line)
-			}
+	for i, cb := range mermaidBlocks {
+		b := new(MermaidBlock)
+		b.SetLines(cb.Lines())
+		// Mark the first Mermaid block so the renderer injects the runtime
+		// script exactly once per document; this is decided here, at
+		// transform time, since the node renderer itself has no access to
+		// parser.Context.
+		if i == 0 {
+			b.SetAttributeString(mermaidFirstAttr, true)
 		}
+		replaceWithBlock(cb, b)
 	}
 
-	if chart_type == "" {
-		return RenderChartData{}, errors.New("layout not found")
-	}
-	if len(data_lines) == 0 {
-		return RenderChartData{}, errors.New("data not found")
-	}
-
-	// Join and normalize to valid JSON
-	data_str := strings.Join(data_lines, "\n")
-	data_str = strings.TrimSuffix(data_str, "]")
-	data_str = strings.TrimSpace(data_str)
-	if !strings.HasPrefix(data_str, "[") {
-		data_str = "[" + data_str
-	}
-	if !strings.HasSuffix(data_str, "]") {
-		data_str = data_str + "]"
-	}
-
-	// Replace loose keys and quotes to JSON-compatible
-	data_str = strings.ReplaceAll(data_str, "key:", `"key":`)
-	data_str = strings.ReplaceAll(data_str, "value:", `"value":`)
-	data_str = strings.ReplaceAll(data_str, "'", `"`)
-	data_str = strings.ReplaceAll(data_str, ", }", "}")
-	data_str = strings.ReplaceAll(data_str, ",]", "]")
-
-	var points []RenderPoint
-	if err := json.Unmarshal([]byte(data_str), &points); err != nil {
-		return RenderChartData{}, fmt.Errorf("failed to parse chart data: %w", err)
-	}
-
-	keys_numeric := true
-	for _, p := range points {
-		key, ok := p.Key.(string)
-		if ok {
-			if _, err := fmt.Sscanf(key, "%v", new(float64)); err != nil {
-				keys_numeric = false
-				break
-			}
-		}
+	for _, cb := range goatBlocks {
+		b := new(GoatBlock)
+		b.SetLines(cb.Lines())
+		replaceWithBlock(cb, b)
 	}
-
-	return RenderChartData{
-		Type:        chart_type,
-		Height:      chart_height,
-		Label:       chart_label,
-		Title:       chart_title,
-		Points:      points,
-		KeysNumeric: keys_numeric,
-		Color:       chart_color,
-	}, nil
 }
 
-func BuildChartJS(div_id string, cd RenderChartData) string {
-	// Normalize type
-	t := strings.ToLower(strings.TrimSpace(cd.Type))
-	switch t {
-	case "bar", "line", "pie":
-	default:
-		t = "bar"
-	}
-
-	// Prepare labels and values
-	labels := make([]interface{}, len(cd.Points))
-	values := make([]float64, len(cd.Points))
-	for i, p := range cd.Points {
-		labels[i] = p.Key
-		values[i] = p.Value
-	}
-	labelsJSON, _ := json.Marshal(labels)
-	valuesJSON, _ := json.Marshal(values)
-
-	// UI color (text/grid). Default if not provided.
-	uiColor := cd.Color
-	if strings.TrimSpace(uiColor) == "" {
-		uiColor = "#dddddd"
-	}
-	uiColorJSON, _ := json.Marshal(uiColor)
-
-	// Title text: prefer Title; fallback to Label; if both empty, hide title.
-	titleText := cd.Title
-	if strings.TrimSpace(titleText) == "" {
-		titleText = cd.Label
-	}
-	titleJSON, _ := json.Marshal(titleText)
-	titleDisplay := "false"
-	if strings.TrimSpace(titleText) != "" {
-		titleDisplay = "true"
+func replaceWithBlock(cb *ast.FencedCodeBlock, b ast.Node) {
+	parent := cb.Parent()
+	if parent != nil {
+		parent.ReplaceChild(parent, cb, b)
 	}
+}
 
-	// Dataset (no explicit colors -> keep Chart.js defaults)
-	dataset := fmt.Sprintf(`{
-		label: %q,
-		data: %s,
-		borderWidth: 1
-	}`, cd.Label, valuesJSON)
-
-	// Options: style only the UI
-	options := ""
-	if t == "pie" {
-		// Pie has no scales; just legend + title styling
-		options = fmt.Sprintf(`{
-			responsive: true,
-			maintainAspectRatio: false,
-			plugins: {
-				legend: { labels: { color: %s } },
-				title: { display: %s, text: %s, color: %s }
-			}
-		}`, uiColorJSON, titleDisplay, titleJSON, uiColorJSON)
-	} else {
-		// Bar/Line: add axes styling and subtle gridline color
-		options = fmt.Sprintf(`{
-			responsive: true,
-			maintainAspectRatio: false,
-			plugins: {
-				legend: { labels: { color: %s } },
-				title:  { display: %s, text: %s, color: %s }
-			},
-			scales: {
-				x: {
-					ticks: { color: %s },
-					grid:  { color: "rgba(255,255,255,0.1)" }
-				},
-				y: {
-					ticks: { color: %s },
-					grid:  { color: "rgba(255,255,255,0.1)" }
-				}
-			}
-		}`, uiColorJSON, titleDisplay, titleJSON, uiColorJSON, uiColorJSON, uiColorJSON)
-	}
+type HTMLRenderer struct {
+	// MermaidCDN is the URL the injected <script> tag imports Mermaid from.
+	// Empty means defaultMermaidCDN.
+	MermaidCDN string
+
+	// Renderer produces the markup for a ChartBlock. Defaults to
+	// ChartJSRenderer, which reproduces the original Chart.js behavior.
+	// Only consulted through the default ChartJSBuilder; set ChartRenderer
+	// directly to bypass it.
+	Renderer Renderer
+
+	// ChartRenderer produces the open/body/close markup for a ChartBlock.
+	// Defaults to &ChartJSBuilder{Renderer: Renderer}.
+	ChartRenderer ChartRenderer
+
+	// Schema, when set, validates a chart block's raw YAML before it is
+	// parsed and rendered. A failing block renders a visible
+	// `<div class="chart-error">` instead of being silently dropped.
+	Schema *jsonschema.Schema
+}
 
-	return fmt.Sprintf(`
-	<script defer>
-		(function () {
-			const ctx = document.getElementById("%s").getContext("2d");
-			const labels = %s;
-			const data = {
-				labels,
-				datasets: [%s]
-			};
-			const config = {
-				type: %q,
-				data,
-				options: %s
-			};
-			new Chart(ctx, config);
-		})();
-	</script>`, div_id, labelsJSON, dataset, t, options)
+func (r *HTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindChartBlock, r.Render)
+	reg.Register(KindMermaidBlock, r.RenderMermaid)
+	reg.Register(KindGoatBlock, r.RenderGoat)
 }
 
 // Finally render
@@ -307,9 +148,16 @@ func (r *HTMLRenderer) Render(w util.BufWriter, src []byte, node ast.Node, enter
 		return ast.WalkContinue, nil
 	}
 
-	out := []byte{}
+	if r.Schema != nil {
+		if schemaErr := validateChartConfig(r.Schema, string(input_b)); schemaErr != nil {
+			line := lineAt(src, lines.At(0).Start)
+			_, err := w.Write(renderChartError(schemaErr, line))
+			return ast.WalkContinue, err
+		}
+	}
 
-	// Generate ID for div
+	// Generate ID for div, and a cache key shared by every Renderer
+	// implementation.
 	div_id_hash := sha256.New()
 	div_id_hash.Write(input_b)
 	div_id := hex.EncodeToString(div_id_hash.Sum(nil))
@@ -317,30 +165,190 @@ func (r *HTMLRenderer) Render(w util.BufWriter, src []byte, node ast.Node, enter
 	// Get chart data
 	chart_data, err := ParseChartData(string(input_b))
 	if err != nil {
-		// Currently just ignore
 		return ast.WalkContinue, err
 	}
+	chart_data.Hash = div_id
 
-	out = append(out, []byte(fmt.Sprintf(`<div style="position:relative;width:100%%;height:%s"><canvas id="%s"></canvas></div>`, chart_data.Height, div_id))...)
-
-	out = append(out, []byte(BuildChartJS(div_id, chart_data))...)
+	cr := r.ChartRenderer
+	if cr == nil {
+		cr = &ChartJSBuilder{Renderer: r.Renderer}
+	}
 
-	_, err = w.Write(out)
-	return ast.WalkContinue, err
+	if err := cr.RenderOpen(w, chart_data); err != nil {
+		return ast.WalkContinue, err
+	}
+	if err := cr.RenderBody(w, chart_data); err != nil {
+		return ast.WalkContinue, err
+	}
+	if err := cr.RenderClose(w, chart_data); err != nil {
+		return ast.WalkContinue, err
+	}
+	return ast.WalkContinue, nil
 }
 
 // Instance used as extension
 type Chart struct {
-	// Options
+	EnableMermaid bool
+	EnableGoat    bool
+	MermaidCDN    string
+
+	// Renderer produces the markup for `vis` chart blocks. Defaults to
+	// ChartJSRenderer when nil. Ignored if ChartRenderer is set.
+	Renderer Renderer
+
+	// ChartRenderer overrides the open/body/close markup for `vis` chart
+	// blocks. Defaults to &ChartJSBuilder{Renderer: Renderer} when nil. See
+	// WithChartRenderer.
+	ChartRenderer ChartRenderer
+
+	// chartTypes holds any chart types registered via RegisterChartType, so
+	// each Chart instance (and thus each goldmark.Markdown) can carry its
+	// own set without affecting others. Only consulted when Renderer is
+	// nil, since it configures ChartJSRenderer specifically.
+	chartTypes *ChartTypeRegistry
+
+	// Schema, when set, validates each chart block before rendering it.
+	// See WithSchema.
+	Schema *jsonschema.Schema
+}
+
+// RegisterChartType adds or overrides the ChartTypeRenderer used for layout
+// name on this Chart instance's default ChartJSRenderer. It has no effect
+// if a custom Renderer has been set via WithRenderer.
+func (e *Chart) RegisterChartType(name string, r ChartTypeRenderer) {
+	if e.chartTypes == nil {
+		e.chartTypes = NewChartTypeRegistry()
+	}
+	e.chartTypes.RegisterChartType(name, r)
+}
+
+// NewChart builds a Chart extension with the given Options applied on top
+// of the defaults (only `vis` charts enabled, Mermaid/Goat opt-in,
+// Chart.js-backed rendering).
+func NewChart(opts ...Option) *Chart {
+	c := &Chart{
+		MermaidCDN: defaultMermaidCDN,
+	}
+	for _, o := range opts {
+		o.SetChartOption(c)
+	}
+	return c
+}
+
+// Option configures a Chart extension, following the functional-options
+// pattern used throughout the goldmark extension ecosystem.
+type Option interface {
+	SetChartOption(*Chart)
+}
+
+type withMermaid bool
+
+func (o withMermaid) SetChartOption(c *Chart) {
+	c.EnableMermaid = bool(o)
+}
+
+// WithMermaid enables/disables rendering of ```mermaid fenced code blocks.
+func WithMermaid(enabled bool) Option {
+	return withMermaid(enabled)
+}
+
+type withGoat bool
+
+func (o withGoat) SetChartOption(c *Chart) {
+	c.EnableGoat = bool(o)
+}
+
+// WithGoat enables/disables rendering of ```goat fenced code blocks.
+func WithGoat(enabled bool) Option {
+	return withGoat(enabled)
+}
+
+type withMermaidCDN string
+
+func (o withMermaidCDN) SetChartOption(c *Chart) {
+	c.MermaidCDN = string(o)
+}
+
+// WithMermaidCDN overrides the URL the Mermaid runtime is imported from.
+func WithMermaidCDN(url string) Option {
+	return withMermaidCDN(url)
+}
+
+type withRenderer struct {
+	r Renderer
+}
+
+func (o withRenderer) SetChartOption(c *Chart) {
+	c.Renderer = o.r
+}
+
+// WithRenderer selects the Renderer used for `vis` chart blocks, e.g. an
+// SVGRenderer for JS-free, statically rendered output.
+func WithRenderer(r Renderer) Option {
+	return withRenderer{r}
+}
+
+type withSchema struct {
+	s *jsonschema.Schema
+}
+
+func (o withSchema) SetChartOption(c *Chart) {
+	c.Schema = o.s
+}
+
+// WithSchema validates every chart block against s (see DefaultSchema)
+// before rendering it, turning malformed blocks into a visible
+// `<div class="chart-error">` instead of a silently dropped block or a
+// goldmark error that's hard to trace back to its source.
+func WithSchema(s *jsonschema.Schema) Option {
+	return withSchema{s}
+}
+
+type withChartRenderer struct {
+	cr ChartRenderer
+}
+
+func (o withChartRenderer) SetChartOption(c *Chart) {
+	c.ChartRenderer = o.cr
+}
+
+// WithChartRenderer overrides the ChartRenderer used for `vis` chart
+// blocks, e.g. to wrap every chart in a <figure>/<figcaption> or tie it
+// into a site's existing shortcode system, without reimplementing
+// ChartBlock parsing.
+func WithChartRenderer(cr ChartRenderer) Option {
+	return withChartRenderer{cr}
 }
 
 func (e *Chart) Extend(m goldmark.Markdown) {
 	m.Parser().AddOptions(parser.WithASTTransformers(
-		util.Prioritized(&Transformer{}, 100),
+		util.Prioritized(&Transformer{
+			EnableMermaid: e.EnableMermaid,
+			EnableGoat:    e.EnableGoat,
+		}, 100),
 	))
+
+	rnd := e.Renderer
+	if rnd == nil && e.chartTypes != nil {
+		rnd = &ChartJSRenderer{Registry: e.chartTypes}
+	}
+
+	// goldmark registers NodeRenderers from highest priority down to
+	// lowest, and a later registration for a given kind overwrites an
+	// earlier one — so the *lowest* priority number actually wins.
+	// Built-in goldmark extensions (table, footnote, strikethrough,
+	// tasklist, definition_list) conventionally register their
+	// NodeRenderer at priority 500. Using a priority well above that here
+	// means this renderer is registered first and a conventionally-written
+	// downstream extension overriding KindChartBlock registers after it
+	// and wins, as intended.
+	const chartNodeRendererPriority = 1000
 	m.Renderer().AddOptions(renderer.WithNodeRenderers(
 		util.Prioritized(&HTMLRenderer{
-			// Options
-		}, 0),
+			MermaidCDN:    e.MermaidCDN,
+			Renderer:      rnd,
+			ChartRenderer: e.ChartRenderer,
+			Schema:        e.Schema,
+		}, chartNodeRendererPriority),
 	))
 }
@@ -0,0 +1,53 @@
+package goldmark_chart
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// overrideChartRenderer is a dummy NodeRenderer for KindChartBlock,
+// standing in for a conventionally-written downstream extension.
+type overrideChartRenderer struct{}
+
+func (overrideChartRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindChartBlock, overrideChartRenderer{}.render)
+}
+
+func (overrideChartRenderer) render(w util.BufWriter, src []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.Write([]byte("<div class=\"overridden\"></div>"))
+	}
+	return ast.WalkContinue, nil
+}
+
+// overrideExtension registers overrideChartRenderer at the conventional
+// priority (500) goldmark's own built-in extensions use for their
+// NodeRenderers.
+type overrideExtension struct{}
+
+func (overrideExtension) Extend(m goldmark.Markdown) {
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(
+		util.Prioritized(overrideChartRenderer{}, 500),
+	))
+}
+
+func TestConventionalOverrideWinsOverChartRenderer(t *testing.T) {
+	md := goldmark.New(goldmark.WithExtensions(NewChart(), overrideExtension{}))
+
+	source := []byte("```vis\nlayout: bar\ndata:\n  - key: Jan\n    value: 1\n```\n")
+
+	var buf bytes.Buffer
+	if err := md.Convert(source, &buf); err != nil {
+		t.Fatalf("Convert returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `class="overridden"`) {
+		t.Fatalf("expected the conventionally-registered override to win, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,47 @@
+package goldmark_chart
+
+import "github.com/yuin/goldmark/util"
+
+// ChartRenderer is the extension surface for customizing chart markup
+// without reimplementing ChartBlock parsing. HTMLRenderer.Render calls
+// into it instead of building markup itself, splitting the work into three
+// hooks so callers can, for example, wrap a chart in a <figure>/<figcaption>
+// or tie it into a site's shortcode system by only overriding RenderOpen
+// and RenderClose.
+type ChartRenderer interface {
+	// RenderOpen writes any markup that belongs before the chart itself.
+	RenderOpen(w util.BufWriter, cd RenderChartData) error
+	// RenderBody writes the chart itself.
+	RenderBody(w util.BufWriter, cd RenderChartData) error
+	// RenderClose writes any markup that belongs after the chart.
+	RenderClose(w util.BufWriter, cd RenderChartData) error
+}
+
+// ChartJSBuilder is the default ChartRenderer. RenderOpen/RenderClose are
+// no-ops; RenderBody delegates to Renderer (defaulting to ChartJSRenderer),
+// reproducing the module's original behavior.
+type ChartJSBuilder struct {
+	Renderer Renderer
+}
+
+func (b *ChartJSBuilder) RenderOpen(w util.BufWriter, cd RenderChartData) error {
+	return nil
+}
+
+func (b *ChartJSBuilder) RenderBody(w util.BufWriter, cd RenderChartData) error {
+	rnd := b.Renderer
+	if rnd == nil {
+		rnd = &ChartJSRenderer{}
+	}
+
+	out, err := rnd.Render(cd)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+func (b *ChartJSBuilder) RenderClose(w util.BufWriter, cd RenderChartData) error {
+	return nil
+}
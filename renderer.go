@@ -0,0 +1,8 @@
+package goldmark_chart
+
+// Renderer produces the HTML markup for a single chart block. Implementations
+// are free to target Chart.js, an in-process SVG backend, or anything else;
+// HTMLRenderer only depends on this interface.
+type Renderer interface {
+	Render(cd RenderChartData) ([]byte, error)
+}
@@ -0,0 +1,88 @@
+package goldmark_chart
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"html"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed chart_schema.json
+var chartSchemaJSON []byte
+
+// DefaultSchema compiles the JSON Schema describing the ChartConfig
+// surface: the allowed chart types, the required `layout` and
+// `data`/`datasets` fields, the type of every field, and the format of the
+// top-level `color` and `height`. Required fields are the same for every
+// chart type, numeric values are not bounded, and a dataset's `color` is
+// not format-checked. Pass a tightened copy (e.g. restricting `layout` to a
+// corporate chart type, or `color` to a brand palette) to WithSchema to
+// enforce stricter rules without forking this module.
+func DefaultSchema() *jsonschema.Schema {
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource("chart_schema.json", bytes.NewReader(chartSchemaJSON)); err != nil {
+		panic(fmt.Sprintf("goldmark-chart: embedded schema is invalid: %v", err))
+	}
+	return c.MustCompile("chart_schema.json")
+}
+
+// SchemaError carries enough context about a failed validation to render a
+// visible `<div class="chart-error">` instead of silently dropping the
+// chart block.
+type SchemaError struct {
+	SchemaPath string
+	Value      interface{}
+	Message    string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("chart config failed schema validation at %s: %s", e.SchemaPath, e.Message)
+}
+
+// validateChartConfig decodes input as YAML and validates it against
+// schema, returning nil when the block is valid.
+func validateChartConfig(schema *jsonschema.Schema, input string) *SchemaError {
+	var doc interface{}
+	if err := yaml.Unmarshal([]byte(input), &doc); err != nil {
+		return &SchemaError{Message: fmt.Sprintf("invalid YAML: %s", err)}
+	}
+
+	if err := schema.Validate(doc); err != nil {
+		ve, ok := err.(*jsonschema.ValidationError)
+		if !ok {
+			return &SchemaError{Message: err.Error()}
+		}
+		// BasicOutput flattens the cause tree; the last entry is the most
+		// specific leaf, which is almost always the useful one to surface.
+		causes := ve.BasicOutput().Errors
+		leaf := causes[len(causes)-1]
+		return &SchemaError{
+			SchemaPath: leaf.KeywordLocation,
+			Value:      leaf.InstanceLocation,
+			Message:    leaf.Error,
+		}
+	}
+	return nil
+}
+
+// renderChartError renders a *SchemaError as a visible div, so a broken
+// chart block is easy to spot in the rendered document instead of being
+// silently dropped.
+func renderChartError(err *SchemaError, line int) []byte {
+	return []byte(fmt.Sprintf(
+		`<div class="chart-error"><p>chart config error at line %d</p><p>schema path: <code>%s</code></p><p>%s</p></div>`,
+		line, html.EscapeString(err.SchemaPath), html.EscapeString(err.Message),
+	))
+}
+
+// lineAt returns the 1-indexed line number containing byte offset pos in
+// src.
+func lineAt(src []byte, pos int) int {
+	if pos > len(src) {
+		pos = len(src)
+	}
+	return bytes.Count(src[:pos], []byte("\n")) + 1
+}
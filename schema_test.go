@@ -0,0 +1,94 @@
+package goldmark_chart
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateChartConfigValid(t *testing.T) {
+	input := `
+layout: bar
+data:
+  - key: Jan
+    value: 1
+`
+	if err := validateChartConfig(DefaultSchema(), input); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestValidateChartConfigInvalidEnum(t *testing.T) {
+	input := `
+layout: not-a-real-layout
+data:
+  - key: Jan
+    value: 1
+`
+	err := validateChartConfig(DefaultSchema(), input)
+	if err == nil {
+		t.Fatalf("expected an error for an invalid layout enum value")
+	}
+	if err.SchemaPath == "" {
+		t.Fatalf("expected SchemaPath to be populated, got: %+v", err)
+	}
+}
+
+func TestValidateChartConfigMissingRequiredField(t *testing.T) {
+	input := `
+height: 400px
+`
+	err := validateChartConfig(DefaultSchema(), input)
+	if err == nil {
+		t.Fatalf("expected an error for a missing required layout field")
+	}
+}
+
+func TestValidateChartConfigMissingDataAndDatasets(t *testing.T) {
+	input := `
+layout: bar
+height: 400px
+`
+	err := validateChartConfig(DefaultSchema(), input)
+	if err == nil {
+		t.Fatalf("expected an error for a layout-only block with neither data nor datasets")
+	}
+}
+
+func TestValidateChartConfigDatasetsOnlySatisfiesSchema(t *testing.T) {
+	input := `
+layout: bar
+datasets:
+  - label: Series A
+    data:
+      - key: Jan
+        value: 1
+`
+	if err := validateChartConfig(DefaultSchema(), input); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+}
+
+func TestValidateChartConfigInvalidYAML(t *testing.T) {
+	input := "layout: bar\ndata: [\n"
+	err := validateChartConfig(DefaultSchema(), input)
+	if err == nil {
+		t.Fatalf("expected an error for invalid YAML")
+	}
+	if !strings.Contains(err.Message, "invalid YAML") {
+		t.Fatalf("expected message to call out invalid YAML, got: %q", err.Message)
+	}
+}
+
+func TestRenderChartErrorEscapesHTML(t *testing.T) {
+	err := &SchemaError{
+		SchemaPath: "#/properties/layout",
+		Message:    `<script>alert("x")</script>`,
+	}
+	out := string(renderChartError(err, 3))
+	if strings.Contains(out, "<script>") {
+		t.Fatalf("expected schema error message to be HTML-escaped, got: %s", out)
+	}
+	if !strings.Contains(out, "line 3") {
+		t.Fatalf("expected rendered error to mention the line number, got: %s", out)
+	}
+}
@@ -0,0 +1,155 @@
+package goldmark_chart
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	chart "github.com/wcharczuk/go-chart/v2"
+)
+
+// SVGRenderer renders a chart block to a self-contained inline <svg> at
+// build time, so the resulting document needs no client-side JS and no
+// Chart.js CDN. Output is cached on disk, keyed by cd.Hash, so repeated
+// builds don't re-render unchanged charts.
+type SVGRenderer struct {
+	// CacheDir, if set, is used to store/reuse rendered SVGs across builds.
+	// Leave empty to always render.
+	CacheDir string
+}
+
+func (r *SVGRenderer) Render(cd RenderChartData) ([]byte, error) {
+	if r.CacheDir != "" {
+		if cached, err := os.ReadFile(r.cachePath(cd.Hash)); err == nil {
+			return wrapSVG(cached), nil
+		}
+	}
+
+	svg, err := renderGoChart(cd)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.CacheDir != "" {
+		if err := os.MkdirAll(r.CacheDir, 0o755); err == nil {
+			_ = os.WriteFile(r.cachePath(cd.Hash), svg, 0o644)
+		}
+	}
+
+	return wrapSVG(svg), nil
+}
+
+func (r *SVGRenderer) cachePath(hash string) string {
+	return filepath.Join(r.CacheDir, hash+".svg")
+}
+
+func wrapSVG(svg []byte) []byte {
+	return append([]byte(`<div class="chart-svg">`), append(svg, []byte(`</div>`)...)...)
+}
+
+// renderGoChart draws cd using wcharczuk/go-chart and returns the raw SVG.
+func renderGoChart(cd RenderChartData) ([]byte, error) {
+	t := strings.ToLower(strings.TrimSpace(cd.Type))
+	switch t {
+	case "bar", "line", "pie":
+	default:
+		t = "bar"
+	}
+
+	sets := chartDatasets(cd)
+
+	buf := &bytes.Buffer{}
+
+	// chart.Chart, chart.PieChart, chart.BarChart and chart.StackedBarChart
+	// each expose their own Render method (there's no shared Renderable
+	// interface in wcharczuk/go-chart/v2), so build and render the concrete
+	// type directly per chart type.
+	switch t {
+	case "pie":
+		// go-chart's PieChart has no multi-series concept, so only the
+		// first dataset is rendered, matching the module's original
+		// single-dataset behavior.
+		points := sets[0].Points
+		pieValues := make([]chart.Value, len(points))
+		for i, p := range points {
+			pieValues[i] = chart.Value{Label: fmt.Sprintf("%v", p.Key), Value: p.Value}
+		}
+		pc := chart.PieChart{
+			Title:  cd.Title,
+			Width:  512,
+			Height: 512,
+			Values: pieValues,
+		}
+		if err := pc.Render(chart.SVG, buf); err != nil {
+			return nil, fmt.Errorf("failed to render chart to svg: %w", err)
+		}
+	case "bar":
+		if cd.Stacked && len(sets) > 1 {
+			// StackedBarChart groups one StackedBar per category, each
+			// holding one Value per dataset, keyed by position since
+			// go-chart has no notion of matching points by Key across
+			// series.
+			bars := make([]chart.StackedBar, len(sets[0].Points))
+			for i := range sets[0].Points {
+				values := make([]chart.Value, len(sets))
+				for j, ds := range sets {
+					values[j] = chart.Value{Label: ds.Label, Value: ds.Points[i].Value}
+				}
+				bars[i] = chart.StackedBar{
+					Name:   fmt.Sprintf("%v", sets[0].Points[i].Key),
+					Values: values,
+				}
+			}
+			sbc := chart.StackedBarChart{
+				Title: cd.Title,
+				Bars:  bars,
+			}
+			if err := sbc.Render(chart.SVG, buf); err != nil {
+				return nil, fmt.Errorf("failed to render chart to svg: %w", err)
+			}
+			break
+		}
+
+		// go-chart's BarChart has no multi-series concept, so only the
+		// first dataset is rendered, matching the pie chart's behavior
+		// above.
+		points := sets[0].Points
+		barValues := make([]chart.Value, len(points))
+		for i, p := range points {
+			barValues[i] = chart.Value{Label: fmt.Sprintf("%v", p.Key), Value: p.Value}
+		}
+		bc := chart.BarChart{
+			Title: cd.Title,
+			Bars:  barValues,
+		}
+		if err := bc.Render(chart.SVG, buf); err != nil {
+			return nil, fmt.Errorf("failed to render chart to svg: %w", err)
+		}
+	default:
+		series := make([]chart.Series, len(sets))
+		for i, ds := range sets {
+			xValues := make([]float64, len(ds.Points))
+			yValues := make([]float64, len(ds.Points))
+			for j, p := range ds.Points {
+				xValues[j] = float64(j)
+				yValues[j] = p.Value
+			}
+			series[i] = chart.ContinuousSeries{
+				Name:    ds.Label,
+				XValues: xValues,
+				YValues: yValues,
+			}
+		}
+		ch := chart.Chart{
+			Title:  cd.Title,
+			Series: series,
+		}
+		if err := ch.Render(chart.SVG, buf); err != nil {
+			return nil, fmt.Errorf("failed to render chart to svg: %w", err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,138 @@
+package goldmark_chart
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestSVGRendererRender(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "bar",
+		Label: "widgets",
+		Title: "Widgets Sold",
+		Points: []RenderPoint{
+			{Key: "Jan", Value: 1},
+			{Key: "Feb", Value: 2},
+		},
+		Hash: "testhash",
+	}
+
+	r := &SVGRenderer{}
+	out, err := r.Render(cd)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	if !strings.Contains(string(out), `class="chart-svg"`) {
+		t.Fatalf("expected wrapper div, got: %s", out)
+	}
+
+	start := strings.Index(string(out), "<svg")
+	if start == -1 {
+		t.Fatalf("expected an <svg> element, got: %s", out)
+	}
+	end := strings.LastIndex(string(out), "</svg>")
+	if end == -1 {
+		t.Fatalf("expected a closing </svg>, got: %s", out)
+	}
+
+	var doc struct {
+		XMLName xml.Name `xml:"svg"`
+	}
+	if err := xml.Unmarshal(out[start:end+len("</svg>")], &doc); err != nil {
+		t.Fatalf("rendered SVG is not valid XML: %v", err)
+	}
+}
+
+func TestSVGRendererRenderBarUsesBarStyle(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "bar",
+		Label: "widgets",
+		Points: []RenderPoint{
+			{Key: "Jan", Value: 1},
+			{Key: "Feb", Value: 2},
+		},
+		Hash: "barhash",
+	}
+
+	r := &SVGRenderer{}
+	out, err := r.Render(cd)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	// go-chart's SVG renderer draws every shape as a <path>, so bar vs.
+	// line is distinguished by BarChart's bar style, whose default stroke
+	// width (3) is otherwise never used by a line chart's series/axes.
+	if !strings.Contains(string(out), "stroke-width:3") {
+		t.Fatalf("expected bar chart output to contain a bar drawn with BarChart's default stroke width, got: %s", out)
+	}
+}
+
+func TestSVGRendererRenderLineOmitsBarStyle(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "line",
+		Label: "widgets",
+		Points: []RenderPoint{
+			{Key: "Jan", Value: 1},
+			{Key: "Feb", Value: 2},
+		},
+		Hash: "linehash",
+	}
+
+	r := &SVGRenderer{}
+	out, err := r.Render(cd)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	if strings.Contains(string(out), "stroke-width:3") {
+		t.Fatalf("expected line chart output not to use BarChart's bar stroke width, got: %s", out)
+	}
+	if !strings.Contains(string(out), "<path") {
+		t.Fatalf("expected line chart output to contain a <path> element, got: %s", out)
+	}
+}
+
+func TestSVGRendererRenderStackedBarUsesMultipleDatasets(t *testing.T) {
+	cd := RenderChartData{
+		Type:    "bar",
+		Stacked: true,
+		Datasets: []RenderDataset{
+			{Label: "Series A", Points: []RenderPoint{{Key: "Jan", Value: 1}, {Key: "Feb", Value: 2}}},
+			{Label: "Series B", Points: []RenderPoint{{Key: "Jan", Value: 3}, {Key: "Feb", Value: 4}}},
+		},
+		Hash: "stackedbarhash",
+	}
+
+	r := &SVGRenderer{}
+	out, err := r.Render(cd)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(string(out), "Series A") || !strings.Contains(string(out), "Series B") {
+		t.Fatalf("expected stacked bar chart output to label both datasets, got: %s", out)
+	}
+}
+
+func TestSVGRendererRenderPie(t *testing.T) {
+	cd := RenderChartData{
+		Type:  "pie",
+		Label: "share",
+		Points: []RenderPoint{
+			{Key: "A", Value: 1},
+			{Key: "B", Value: 3},
+		},
+		Hash: "piehash",
+	}
+
+	r := &SVGRenderer{}
+	out, err := r.Render(cd)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if !strings.Contains(string(out), "<svg") {
+		t.Fatalf("expected an <svg> element, got: %s", out)
+	}
+}